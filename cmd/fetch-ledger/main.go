@@ -0,0 +1,174 @@
+// Command fetch-ledger fetches ledger transactions for a named preamble
+// from a kanakku server and writes them in the format chosen by -format
+// (JSON by default) to stdout or the -output file. With -watch it instead
+// streams new transactions as newline-delimited JSON until interrupted.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/baijum/kanakku/pkg/export"
+	"github.com/baijum/kanakku/pkg/kanakku"
+	"github.com/baijum/kanakku/pkg/kanakku/auth"
+)
+
+func main() {
+	tokenFlag := flag.String("token", "", "API Access Token (static token auth)")
+	authModeFlag := flag.String("auth-mode", "static", "Auth mode: static, password, or oidc")
+	preambleNameFlag := flag.String("preamble-name", "", "Preamble Name")
+	formatFlag := flag.String("format", "json", "Output format: json, ledger, hledger, or csv")
+	outputFlag := flag.String("output", "", "Output file path (defaults to stdout)")
+	watchFlag := flag.Bool("watch", false, "Watch for new transactions and stream them as newline-delimited JSON until interrupted")
+	flag.Parse()
+
+	accessToken := *tokenFlag
+	if accessToken == "" {
+		accessToken = os.Getenv("API_ACCESS_TOKEN")
+	}
+	preambleName := *preambleNameFlag
+	if preambleName == "" {
+		preambleName = os.Getenv("PREAMBLE_NAME")
+	}
+	apiBaseURL := os.Getenv("API_BASE_URL")
+
+	if apiBaseURL == "" {
+		fmt.Fprintln(os.Stderr, "Error: API Base URL is required.")
+		fmt.Fprintln(os.Stderr, "Please provide it using the API_BASE_URL environment variable.")
+		os.Exit(1)
+	}
+	if preambleName == "" {
+		fmt.Fprintln(os.Stderr, "Error: Preamble Name is required.")
+		fmt.Fprintln(os.Stderr, "Please provide it using the -preamble-name flag or the PREAMBLE_NAME environment variable.")
+		os.Exit(1)
+	}
+
+	tokenSource, err := buildTokenSource(*authModeFlag, apiBaseURL, accessToken)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if *watchFlag {
+		if err := runWatch(apiBaseURL, tokenSource, preambleName); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := run(apiBaseURL, tokenSource, preambleName, export.Format(*formatFlag), *outputFlag); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// buildTokenSource picks an auth.TokenSource based on mode. "password" and
+// "oidc" read credentials from the environment so they never show up in
+// process listings.
+func buildTokenSource(mode, apiBaseURL, accessToken string) (auth.TokenSource, error) {
+	switch mode {
+	case "static":
+		if accessToken == "" {
+			return nil, fmt.Errorf("API Access Token is required: provide it using the -token flag or the API_ACCESS_TOKEN environment variable")
+		}
+		return auth.NewStaticTokenSource("Token", accessToken), nil
+	case "password":
+		username := os.Getenv("KANAKKU_USERNAME")
+		password := os.Getenv("KANAKKU_PASSWORD")
+		if username == "" || password == "" {
+			return nil, fmt.Errorf("KANAKKU_USERNAME and KANAKKU_PASSWORD must be set for -auth-mode=password")
+		}
+		return auth.NewPasswordGrantSource(apiBaseURL, username, password), nil
+	case "oidc":
+		issuer := os.Getenv("KANAKKU_OIDC_ISSUER")
+		clientID := os.Getenv("KANAKKU_OIDC_CLIENT_ID")
+		if issuer == "" || clientID == "" {
+			return nil, fmt.Errorf("KANAKKU_OIDC_ISSUER and KANAKKU_OIDC_CLIENT_ID must be set for -auth-mode=oidc")
+		}
+		return auth.NewOIDCSource(issuer, clientID), nil
+	default:
+		return nil, fmt.Errorf("unknown -auth-mode %q: want static, password, or oidc", mode)
+	}
+}
+
+func run(apiBaseURL string, tokenSource auth.TokenSource, preambleName string, format export.Format, outputPath string) error {
+	ctx := context.Background()
+	httpClient := &http.Client{Transport: &auth.Transport{Source: tokenSource}}
+	client := kanakku.New(apiBaseURL, kanakku.WithHTTPClient(httpClient))
+
+	preamble, err := client.GetPreambleByName(ctx, preambleName)
+	if err != nil {
+		return fmt.Errorf("getting preamble %q: %w", preambleName, err)
+	}
+	// Re-fetch by ID to pick up Content, which the by-name lookup omits.
+	full, err := client.GetPreamble(ctx, preamble.ID)
+	if err != nil {
+		return fmt.Errorf("getting preamble content %q: %w", preamble.ID, err)
+	}
+	preamble = full
+
+	transactions, err := client.ListLedgerTransactions(ctx, kanakku.ListLedgerTransactionsOptions{
+		PreambleID: preamble.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("listing ledger transactions: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Successfully fetched %d ledger transactions.\n", len(transactions))
+
+	out := os.Stdout
+	if outputPath != "" {
+		f, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("creating output file %q: %w", outputPath, err)
+		}
+		defer f.Close()
+		out = f
+	}
+
+	return export.Write(out, format, preamble, transactions)
+}
+
+// runWatch streams new transactions as newline-delimited JSON until
+// interrupted (Ctrl-C or SIGTERM).
+func runWatch(apiBaseURL string, tokenSource auth.TokenSource, preambleName string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	httpClient := &http.Client{Transport: &auth.Transport{Source: tokenSource}}
+	client := kanakku.New(apiBaseURL, kanakku.WithHTTPClient(httpClient))
+
+	preamble, err := client.GetPreambleByName(ctx, preambleName)
+	if err != nil {
+		return fmt.Errorf("getting preamble %q: %w", preambleName, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Watching for new transactions under preamble %q (Ctrl-C to stop)...\n", preambleName)
+
+	transactions, errs := client.Watch(ctx, kanakku.WatchOptions{PreambleID: preamble.ID})
+	enc := json.NewEncoder(os.Stdout)
+	for {
+		select {
+		case txn, ok := <-transactions:
+			if !ok {
+				return nil
+			}
+			if err := enc.Encode(txn); err != nil {
+				return fmt.Errorf("encoding transaction: %w", err)
+			}
+		case err := <-errs:
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "watch: %v\n", err)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
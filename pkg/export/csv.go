@@ -0,0 +1,31 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/baijum/kanakku/pkg/kanakku"
+)
+
+// writeCSV renders one row per posting, since a transaction may have more
+// than one.
+func writeCSV(w io.Writer, transactions []kanakku.LedgerTransaction) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"id", "date", "description", "account", "amount"}); err != nil {
+		return fmt.Errorf("export: writing CSV header: %w", err)
+	}
+
+	for _, txn := range transactions {
+		for _, p := range txn.Postings {
+			row := []string{txn.ID, txn.Date, txn.Description, p.Account, p.Amount}
+			if err := cw.Write(row); err != nil {
+				return fmt.Errorf("export: writing CSV row: %w", err)
+			}
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
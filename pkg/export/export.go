@@ -0,0 +1,37 @@
+// Package export serializes kanakku ledger transactions into the output
+// formats supported by the fetch-ledger CLI: JSON, plain-text
+// ledger/hledger journals, and CSV.
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/baijum/kanakku/pkg/kanakku"
+)
+
+// Format selects the output serialization.
+type Format string
+
+const (
+	FormatJSON    Format = "json"
+	FormatLedger  Format = "ledger"
+	FormatHledger Format = "hledger"
+	FormatCSV     Format = "csv"
+)
+
+// Write serializes transactions to w in the given format. preamble may be
+// nil; its Content is used as a file header for the ledger and hledger
+// formats and ignored otherwise.
+func Write(w io.Writer, format Format, preamble *kanakku.Preamble, transactions []kanakku.LedgerTransaction) error {
+	switch format {
+	case FormatJSON:
+		return writeJSON(w, transactions)
+	case FormatLedger, FormatHledger:
+		return writeJournal(w, preamble, transactions)
+	case FormatCSV:
+		return writeCSV(w, transactions)
+	default:
+		return fmt.Errorf("export: unknown format %q", format)
+	}
+}
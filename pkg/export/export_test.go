@@ -0,0 +1,76 @@
+package export
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/baijum/kanakku/pkg/kanakku"
+)
+
+func sampleData() (*kanakku.Preamble, []kanakku.LedgerTransaction) {
+	preamble := &kanakku.Preamble{
+		ID:      "1",
+		Name:    "personal",
+		Content: "; Personal ledger\n; Generated by fetch-ledger",
+	}
+	transactions := []kanakku.LedgerTransaction{
+		{
+			ID:          "100",
+			Date:        "2024-01-15",
+			Description: "Coffee Shop",
+			Postings: []kanakku.Posting{
+				{Account: "Expenses:Food:Coffee", Amount: "4.50"},
+				{Account: "Assets:Checking", Amount: "-4.50"},
+			},
+		},
+		{
+			ID:          "101",
+			Date:        "2024-01-16",
+			Description: "Paycheck",
+			Postings: []kanakku.Posting{
+				{Account: "Assets:Checking", Amount: "2500.00"},
+				{Account: "Income:Salary", Amount: "-2500.00"},
+			},
+		},
+	}
+	return preamble, transactions
+}
+
+func TestWrite_Golden(t *testing.T) {
+	preamble, transactions := sampleData()
+
+	cases := []struct {
+		format Format
+		golden string
+	}{
+		{FormatLedger, "testdata/sample.journal"},
+		{FormatHledger, "testdata/sample.journal"},
+		{FormatCSV, "testdata/sample.csv"},
+	}
+
+	for _, tc := range cases {
+		t.Run(string(tc.format), func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := Write(&buf, tc.format, preamble, transactions); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want, err := os.ReadFile(tc.golden)
+			if err != nil {
+				t.Fatalf("reading golden file: %v", err)
+			}
+			if buf.String() != string(want) {
+				t.Errorf("output mismatch for %s\ngot:\n%s\nwant:\n%s", tc.format, buf.String(), want)
+			}
+		})
+	}
+}
+
+func TestWrite_UnknownFormat(t *testing.T) {
+	preamble, transactions := sampleData()
+	var buf bytes.Buffer
+	if err := Write(&buf, Format("xml"), preamble, transactions); err == nil {
+		t.Fatal("expected error for unknown format, got nil")
+	}
+}
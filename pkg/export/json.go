@@ -0,0 +1,18 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/baijum/kanakku/pkg/kanakku"
+)
+
+func writeJSON(w io.Writer, transactions []kanakku.LedgerTransaction) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(transactions); err != nil {
+		return fmt.Errorf("export: encoding JSON: %w", err)
+	}
+	return nil
+}
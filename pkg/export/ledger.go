@@ -0,0 +1,50 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/baijum/kanakku/pkg/kanakku"
+)
+
+// amountColumn is the column (counting from the start of the line) that
+// posting amounts are aligned to, matching the convention used by
+// ledger-cli and hledger journals.
+const amountColumn = 36
+
+// writeJournal renders transactions as a ledger/hledger plain-text
+// journal: an optional preamble header, then one dated header line per
+// transaction followed by its two-space-indented postings.
+func writeJournal(w io.Writer, preamble *kanakku.Preamble, transactions []kanakku.LedgerTransaction) error {
+	bw := bufio.NewWriter(w)
+
+	if preamble != nil && preamble.Content != "" {
+		fmt.Fprintln(bw, strings.TrimRight(preamble.Content, "\n"))
+		fmt.Fprintln(bw)
+	}
+
+	for i, txn := range transactions {
+		if i > 0 {
+			fmt.Fprintln(bw)
+		}
+		fmt.Fprintf(bw, "%s %s\n", txn.Date, txn.Description)
+		for _, p := range txn.Postings {
+			fmt.Fprintln(bw, postingLine(p))
+		}
+	}
+
+	return bw.Flush()
+}
+
+// postingLine renders a single posting with its amount aligned to
+// amountColumn, separated from the account by at least two spaces.
+func postingLine(p kanakku.Posting) string {
+	const indent = "  "
+	gap := amountColumn - len(indent) - len(p.Account)
+	if gap < 2 {
+		gap = 2
+	}
+	return indent + p.Account + strings.Repeat(" ", gap) + p.Amount
+}
@@ -0,0 +1,180 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestStaticTokenSource(t *testing.T) {
+	src := NewStaticTokenSource("Token", "abc123")
+	token, err := src.Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.Scheme != "Token" || token.AccessToken != "abc123" {
+		t.Errorf("unexpected token: %+v", token)
+	}
+}
+
+func TestPasswordGrantSource_LoginAndRefresh(t *testing.T) {
+	var logins, refreshes int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var form map[string]string
+		json.NewDecoder(r.Body).Decode(&form)
+
+		switch form["grant_type"] {
+		case "password":
+			logins++
+			json.NewEncoder(w).Encode(passwordGrantResponse{
+				AccessToken: "first-token", RefreshToken: "refresh-1", ExpiresIn: 3600,
+			})
+		case "refresh_token":
+			refreshes++
+			if form["refresh_token"] != "refresh-1" {
+				t.Errorf("unexpected refresh token: %s", form["refresh_token"])
+			}
+			json.NewEncoder(w).Encode(passwordGrantResponse{
+				AccessToken: "second-token", RefreshToken: "refresh-2", ExpiresIn: 3600,
+			})
+		default:
+			t.Errorf("unexpected grant_type: %s", form["grant_type"])
+		}
+	}))
+	defer server.Close()
+
+	src := NewPasswordGrantSource(server.URL, "alice", "hunter2", WithPasswordGrantCache(nil))
+
+	token, err := src.Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error logging in: %v", err)
+	}
+	if token.AccessToken != "first-token" {
+		t.Errorf("unexpected token: %+v", token)
+	}
+
+	src.Invalidate()
+	token, err = src.Token(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error refreshing: %v", err)
+	}
+	if token.AccessToken != "second-token" {
+		t.Errorf("expected Invalidate to use the refresh token rather than re-login, got %+v", token)
+	}
+
+	if logins != 1 || refreshes != 1 {
+		t.Errorf("unexpected call counts: logins=%d refreshes=%d, want 1 and 1", logins, refreshes)
+	}
+}
+
+type fakeSource struct {
+	tokens      []*Token
+	idx         int
+	invalidated bool
+}
+
+func (f *fakeSource) Token(_ context.Context) (*Token, error) {
+	t := f.tokens[f.idx]
+	if f.idx < len(f.tokens)-1 {
+		f.idx++
+	}
+	return t, nil
+}
+
+func (f *fakeSource) Invalidate() {
+	f.invalidated = true
+}
+
+func TestTransport_RetriesOnceAfter401(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		seen = append(seen, auth)
+		if auth == "Bearer stale" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	src := &fakeSource{tokens: []*Token{
+		{Scheme: "Bearer", AccessToken: "stale"},
+		{Scheme: "Bearer", AccessToken: "fresh"},
+	}}
+	client := &http.Client{Transport: &Transport{Source: src}}
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("unexpected final status: %s", resp.Status)
+	}
+	if !src.invalidated {
+		t.Error("expected Invalidate to be called after a 401")
+	}
+	if len(seen) != 2 || seen[0] != "Bearer stale" || seen[1] != "Bearer fresh" {
+		t.Errorf("unexpected Authorization headers sent: %v", seen)
+	}
+}
+
+// TestTransport_PasswordGrant401RefreshRetry drives a real PasswordGrantSource
+// (not a fake) through the Transport: the API rejects the first access
+// token with a 401, and the retried request must carry a token obtained
+// via the refresh_token grant rather than a full re-login.
+func TestTransport_PasswordGrant401RefreshRetry(t *testing.T) {
+	var logins, refreshes int
+
+	apiServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer first-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer apiServer.Close()
+
+	loginServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var form map[string]string
+		json.NewDecoder(r.Body).Decode(&form)
+
+		switch form["grant_type"] {
+		case "password":
+			logins++
+			json.NewEncoder(w).Encode(passwordGrantResponse{
+				AccessToken: "first-token", RefreshToken: "refresh-1", ExpiresIn: 3600,
+			})
+		case "refresh_token":
+			refreshes++
+			json.NewEncoder(w).Encode(passwordGrantResponse{
+				AccessToken: "second-token", RefreshToken: "refresh-2", ExpiresIn: 3600,
+			})
+		default:
+			t.Errorf("unexpected grant_type: %s", form["grant_type"])
+		}
+	}))
+	defer loginServer.Close()
+
+	src := NewPasswordGrantSource(loginServer.URL, "alice", "hunter2", WithPasswordGrantCache(nil))
+	client := &http.Client{Transport: &Transport{Source: src}}
+
+	req, _ := http.NewRequest("GET", apiServer.URL, nil)
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("unexpected final status: %s", resp.Status)
+	}
+	if logins != 1 || refreshes != 1 {
+		t.Errorf("unexpected call counts: logins=%d refreshes=%d, want 1 and 1", logins, refreshes)
+	}
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// FileCache persists a Token as JSON on disk, so interactive logins and
+// password-grant refreshes don't re-run on every CLI invocation.
+type FileCache struct {
+	path string
+}
+
+// NewFileCache returns a FileCache backed by the file at path.
+func NewFileCache(path string) *FileCache {
+	return &FileCache{path: path}
+}
+
+// DefaultCachePath returns $XDG_CONFIG_HOME/kanakku/token.json, falling
+// back to os.UserConfigDir when XDG_CONFIG_HOME is unset.
+func DefaultCachePath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		var err error
+		dir, err = os.UserConfigDir()
+		if err != nil {
+			return "", fmt.Errorf("auth: resolving config dir: %w", err)
+		}
+	}
+	return filepath.Join(dir, "kanakku", "token.json"), nil
+}
+
+// Load reads the cached token, if any. It returns (nil, nil) if the cache
+// file does not exist.
+func (c *FileCache) Load() (*Token, error) {
+	data, err := os.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("auth: reading token cache: %w", err)
+	}
+
+	var token Token
+	if err := json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("auth: decoding token cache: %w", err)
+	}
+	return &token, nil
+}
+
+// Save writes token to the cache file with mode 0600, creating its parent
+// directory (mode 0700) if needed.
+func (c *FileCache) Save(token *Token) error {
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return fmt.Errorf("auth: creating token cache dir: %w", err)
+	}
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("auth: encoding token cache: %w", err)
+	}
+	if err := os.WriteFile(c.path, data, 0600); err != nil {
+		return fmt.Errorf("auth: writing token cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,273 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// OIDCSource authenticates interactively via an OIDC/OAuth2
+// authorization-code flow, running a local callback server to receive the
+// redirect. The resulting token is cached and transparently refreshed.
+type OIDCSource struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	scopes       []string
+	httpClient   *http.Client
+	cache        *FileCache
+	// prompt, if set, is called with the URL the user should open in a
+	// browser to authenticate. Defaults to printing it to stdout.
+	prompt func(authURL string)
+
+	mu          sync.Mutex
+	token       *Token
+	cacheLoaded bool
+}
+
+// OIDCOption configures an OIDCSource.
+type OIDCOption func(*OIDCSource)
+
+// WithOIDCClientSecret sets the client secret for confidential clients.
+func WithOIDCClientSecret(secret string) OIDCOption {
+	return func(s *OIDCSource) { s.clientSecret = secret }
+}
+
+// WithOIDCScopes overrides the requested scopes. Defaults to
+// []string{"openid", "profile"}.
+func WithOIDCScopes(scopes []string) OIDCOption {
+	return func(s *OIDCSource) { s.scopes = scopes }
+}
+
+// WithOIDCHTTPClient overrides the *http.Client used to talk to the
+// issuer's token endpoint. Defaults to http.DefaultClient.
+func WithOIDCHTTPClient(hc *http.Client) OIDCOption {
+	return func(s *OIDCSource) { s.httpClient = hc }
+}
+
+// WithOIDCCache overrides where the token is cached. Defaults to
+// DefaultCachePath().
+func WithOIDCCache(cache *FileCache) OIDCOption {
+	return func(s *OIDCSource) { s.cache = cache }
+}
+
+// WithOIDCPrompt overrides how the authorization URL is surfaced to the
+// user. Defaults to printing it to stdout.
+func WithOIDCPrompt(prompt func(authURL string)) OIDCOption {
+	return func(s *OIDCSource) { s.prompt = prompt }
+}
+
+// NewOIDCSource builds an OIDCSource for the given issuer (e.g.
+// "https://accounts.example.com") and client ID.
+func NewOIDCSource(issuer, clientID string, opts ...OIDCOption) *OIDCSource {
+	s := &OIDCSource{
+		issuer:     strings.TrimRight(issuer, "/"),
+		clientID:   clientID,
+		scopes:     []string{"openid", "profile"},
+		httpClient: http.DefaultClient,
+		prompt: func(authURL string) {
+			fmt.Printf("Open the following URL to log in:\n\n  %s\n\n", authURL)
+		},
+	}
+	if path, err := DefaultCachePath(); err == nil {
+		s.cache = NewFileCache(path)
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Token implements TokenSource, running the interactive login flow only
+// when the cached token is missing or within 60s of expiry and cannot be
+// refreshed.
+func (s *OIDCSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == nil && !s.cacheLoaded && s.cache != nil {
+		s.cacheLoaded = true
+		if cached, err := s.cache.Load(); err == nil {
+			s.token = cached
+		}
+	}
+	if !s.token.needsRefresh() {
+		return s.token, nil
+	}
+
+	if s.token != nil && s.token.RefreshToken != "" {
+		if refreshed, err := s.exchange(ctx, url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {s.token.RefreshToken},
+		}); err == nil {
+			s.token = refreshed
+			s.save()
+			return s.token, nil
+		}
+	}
+
+	token, err := s.login(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.token = token
+	s.save()
+	return s.token, nil
+}
+
+// login runs the authorization-code flow: it starts a local callback
+// server, prompts the user to open the authorization URL, and exchanges
+// the returned code for a token.
+func (s *OIDCSource) login(ctx context.Context) (*Token, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, fmt.Errorf("auth: starting OIDC callback listener: %w", err)
+	}
+	defer listener.Close()
+
+	redirectURI := fmt.Sprintf("http://127.0.0.1:%d/callback", listener.Addr().(*net.TCPAddr).Port)
+	state, err := randomString(16)
+	if err != nil {
+		return nil, err
+	}
+
+	type result struct {
+		code string
+		err  error
+	}
+	resultCh := make(chan result, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("state"); got != state {
+			resultCh <- result{err: fmt.Errorf("auth: unexpected OIDC state %q", got)}
+			http.Error(w, "state mismatch", http.StatusBadRequest)
+			return
+		}
+		if errMsg := r.URL.Query().Get("error"); errMsg != "" {
+			resultCh <- result{err: fmt.Errorf("auth: OIDC authorization failed: %s", errMsg)}
+			http.Error(w, errMsg, http.StatusBadRequest)
+			return
+		}
+		resultCh <- result{code: r.URL.Query().Get("code")}
+		fmt.Fprintln(w, "Login complete, you may close this tab.")
+	})
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+
+	authURL := s.authorizationURL(redirectURI, state)
+	s.prompt(authURL)
+
+	select {
+	case res := <-resultCh:
+		if res.err != nil {
+			return nil, res.err
+		}
+		return s.exchange(ctx, url.Values{
+			"grant_type":   {"authorization_code"},
+			"code":         {res.code},
+			"redirect_uri": {redirectURI},
+		})
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *OIDCSource) authorizationURL(redirectURI, state string) string {
+	q := url.Values{
+		"response_type": {"code"},
+		"client_id":     {s.clientID},
+		"redirect_uri":  {redirectURI},
+		"scope":         {strings.Join(s.scopes, " ")},
+		"state":         {state},
+	}
+	return s.issuer + "/authorize?" + q.Encode()
+}
+
+type oidcTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+func (s *OIDCSource) exchange(ctx context.Context, form url.Values) (*Token, error) {
+	form.Set("client_id", s.clientID)
+	if s.clientSecret != "" {
+		form.Set("client_secret", s.clientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.issuer+"/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("auth: building OIDC token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("auth: OIDC token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("auth: OIDC token endpoint returned non-OK status: %s", resp.Status)
+	}
+
+	var parsed oidcTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("auth: decoding OIDC token response: %w", err)
+	}
+
+	scheme := "Bearer"
+	if parsed.TokenType != "" {
+		scheme = parsed.TokenType
+	}
+	token := &Token{
+		Scheme:       scheme,
+		AccessToken:  parsed.AccessToken,
+		RefreshToken: parsed.RefreshToken,
+	}
+	if parsed.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(parsed.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// Invalidate forces the next call to Token to obtain a new access token
+// rather than reuse the cached one, even if it doesn't look expired yet.
+// The refresh token is kept, so the next Token call can still use the
+// refresh grant instead of falling all the way back to the interactive
+// browser authorization-code flow.
+func (s *OIDCSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == nil {
+		return
+	}
+	s.token = &Token{Scheme: s.token.Scheme, RefreshToken: s.token.RefreshToken}
+}
+
+func (s *OIDCSource) save() {
+	if s.cache == nil {
+		return
+	}
+	_ = s.cache.Save(s.token)
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("auth: generating random state: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PasswordGrantSource authenticates against a kanakku server's
+// /api/v1/auth/login endpoint using a username and password, caching and
+// refreshing the resulting token.
+type PasswordGrantSource struct {
+	loginURL   string
+	username   string
+	password   string
+	httpClient *http.Client
+	cache      *FileCache
+
+	mu          sync.Mutex
+	token       *Token
+	cacheLoaded bool
+}
+
+// PasswordGrantOption configures a PasswordGrantSource.
+type PasswordGrantOption func(*PasswordGrantSource)
+
+// WithPasswordGrantHTTPClient overrides the *http.Client used to talk to
+// the login endpoint. Defaults to http.DefaultClient.
+func WithPasswordGrantHTTPClient(hc *http.Client) PasswordGrantOption {
+	return func(s *PasswordGrantSource) { s.httpClient = hc }
+}
+
+// WithPasswordGrantCache overrides where the token is cached. Defaults to
+// DefaultCachePath().
+func WithPasswordGrantCache(cache *FileCache) PasswordGrantOption {
+	return func(s *PasswordGrantSource) { s.cache = cache }
+}
+
+// NewPasswordGrantSource builds a PasswordGrantSource that logs in against
+// baseURL+"/api/v1/auth/login" with username and password.
+func NewPasswordGrantSource(baseURL, username, password string, opts ...PasswordGrantOption) *PasswordGrantSource {
+	s := &PasswordGrantSource{
+		loginURL:   strings.TrimRight(baseURL, "/") + "/api/v1/auth/login",
+		username:   username,
+		password:   password,
+		httpClient: http.DefaultClient,
+	}
+	if path, err := DefaultCachePath(); err == nil {
+		s.cache = NewFileCache(path)
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+type passwordGrantResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// Token implements TokenSource, logging in (or refreshing) only when the
+// cached token is missing or within 60s of expiry.
+func (s *PasswordGrantSource) Token(ctx context.Context) (*Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token == nil && !s.cacheLoaded && s.cache != nil {
+		s.cacheLoaded = true
+		if cached, err := s.cache.Load(); err == nil {
+			s.token = cached
+		}
+	}
+	if !s.token.needsRefresh() {
+		return s.token, nil
+	}
+
+	if s.token != nil && s.token.RefreshToken != "" {
+		if refreshed, err := s.grant(ctx, map[string]string{
+			"grant_type":    "refresh_token",
+			"refresh_token": s.token.RefreshToken,
+		}); err == nil {
+			s.token = refreshed
+			s.save()
+			return s.token, nil
+		}
+	}
+
+	token, err := s.grant(ctx, map[string]string{
+		"grant_type": "password",
+		"username":   s.username,
+		"password":   s.password,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.token = token
+	s.save()
+	return s.token, nil
+}
+
+func (s *PasswordGrantSource) grant(ctx context.Context, form map[string]string) (*Token, error) {
+	body, err := json.Marshal(form)
+	if err != nil {
+		return nil, fmt.Errorf("auth: encoding login request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", s.loginURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("auth: building login request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("auth: login request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("auth: login returned non-OK status: %s", resp.Status)
+	}
+
+	var parsed passwordGrantResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("auth: decoding login response: %w", err)
+	}
+
+	token := &Token{
+		Scheme:       "Bearer",
+		AccessToken:  parsed.AccessToken,
+		RefreshToken: parsed.RefreshToken,
+	}
+	if parsed.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(parsed.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// Invalidate forces the next call to Token to obtain a new access token
+// rather than reuse the cached one, even if it doesn't look expired yet.
+// The refresh token is kept, so the next Token call can still use the
+// refresh grant instead of falling all the way back to a password login.
+func (s *PasswordGrantSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == nil {
+		return
+	}
+	s.token = &Token{Scheme: s.token.Scheme, RefreshToken: s.token.RefreshToken}
+}
+
+func (s *PasswordGrantSource) save() {
+	if s.cache == nil {
+		return
+	}
+	_ = s.cache.Save(s.token)
+}
@@ -0,0 +1,20 @@
+package auth
+
+import "context"
+
+// StaticTokenSource returns a fixed, never-expiring token. Use it when a
+// token has already been issued out of band (e.g. via -token/env var).
+type StaticTokenSource struct {
+	token *Token
+}
+
+// NewStaticTokenSource wraps a pre-issued access token, sent with the given
+// scheme ("Token" or "Bearer").
+func NewStaticTokenSource(scheme, accessToken string) *StaticTokenSource {
+	return &StaticTokenSource{token: &Token{Scheme: scheme, AccessToken: accessToken}}
+}
+
+// Token implements TokenSource.
+func (s *StaticTokenSource) Token(_ context.Context) (*Token, error) {
+	return s.token, nil
+}
@@ -0,0 +1,42 @@
+// Package auth provides TokenSource implementations for authenticating
+// against a kanakku server: a static pre-issued token, an OAuth2 password
+// grant, and an OIDC authorization-code flow for interactive CLI login.
+// Wrap any TokenSource in a Transport to get an http.RoundTripper that
+// injects the Authorization header, so callers (and pkg/kanakku) never
+// need to know which scheme or flow is in play.
+package auth
+
+import (
+	"context"
+	"time"
+)
+
+// refreshSkew is how far ahead of expiry a token is considered stale and
+// eligible for refresh.
+const refreshSkew = 60 * time.Second
+
+// Token is a cached credential, along with enough information to know when
+// it needs replacing.
+type Token struct {
+	Scheme       string    `json:"scheme"` // "Token" or "Bearer"
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Expiry       time.Time `json:"expiry,omitempty"`
+}
+
+// needsRefresh reports whether t is missing, or expires within refreshSkew.
+func (t *Token) needsRefresh() bool {
+	if t == nil || t.AccessToken == "" {
+		return true
+	}
+	if t.Expiry.IsZero() {
+		return false
+	}
+	return time.Now().Add(refreshSkew).After(t.Expiry)
+}
+
+// TokenSource supplies a valid Token, refreshing or re-authenticating as
+// needed. Implementations must be safe for concurrent use.
+type TokenSource interface {
+	Token(ctx context.Context) (*Token, error)
+}
@@ -0,0 +1,57 @@
+package auth
+
+import "net/http"
+
+// Transport injects an Authorization header sourced from Source into every
+// request, so the rest of the client code can stay scheme-agnostic. Plug it
+// into an *http.Client via the Transport field and pass that client to
+// kanakku.WithHTTPClient.
+type Transport struct {
+	// Source supplies the token for each request. Required.
+	Source TokenSource
+	// Base is the underlying RoundTripper. Defaults to
+	// http.DefaultTransport.
+	Base http.RoundTripper
+}
+
+// invalidator is implemented by TokenSources that can be told their current
+// token was rejected, so the next Token call re-authenticates instead of
+// returning a token that looked unexpired.
+type invalidator interface {
+	Invalidate()
+}
+
+// RoundTrip implements http.RoundTripper. On a 401 response it invalidates
+// the current token (if the TokenSource supports it) and retries the
+// request once with a freshly obtained token.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.roundTripWithToken(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	inv, ok := t.Source.(invalidator)
+	if !ok {
+		return resp, err
+	}
+	resp.Body.Close()
+	inv.Invalidate()
+
+	return t.roundTripWithToken(req)
+}
+
+func (t *Transport) roundTripWithToken(req *http.Request) (*http.Response, error) {
+	token, err := t.Source.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	cloned := req.Clone(req.Context())
+	cloned.Header.Set("Authorization", token.Scheme+" "+token.AccessToken)
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(cloned)
+}
@@ -0,0 +1,152 @@
+// Package kanakku provides a Go client for the kanakku ledger API,
+// used by the fetch-ledger CLI and any future tooling that needs to
+// talk to a running kanakku server.
+package kanakku
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultBaseDelay  = 200 * time.Millisecond
+	defaultMaxDelay   = 5 * time.Second
+)
+
+// Client is a client for the kanakku HTTP API. Create one with New.
+//
+// Authentication is entirely the concern of the *http.Client passed via
+// WithHTTPClient: build one with a Transport from pkg/kanakku/auth (static
+// token, password grant, or OIDC) and Client never needs to know which
+// scheme or flow is in play.
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+// Option configures a Client constructed by New.
+type Option func(*Client)
+
+// WithHTTPClient overrides the *http.Client used for requests. Use this to
+// inject a client whose Transport is an *auth.Transport, or that has
+// custom timeouts or TLS config.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithMaxRetries overrides how many times an idempotent GET is retried on
+// a 5xx or 429 response before giving up. Defaults to 3.
+func WithMaxRetries(n int) Option {
+	return func(c *Client) { c.maxRetries = n }
+}
+
+// New creates a Client for the kanakku API hosted at baseURL. Pass
+// WithHTTPClient with an authenticated *http.Client (see pkg/kanakku/auth)
+// unless the server requires no authentication.
+func New(baseURL string, opts ...Option) *Client {
+	c := &Client{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: http.DefaultClient,
+		maxRetries: defaultMaxRetries,
+		baseDelay:  defaultBaseDelay,
+		maxDelay:   defaultMaxDelay,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// newRequest builds a request with the given method against path (which may be a full URL, as
+// returned by a paginated response's "next" field, or a path relative to
+// the client's base URL) with the given query parameters.
+func (c *Client) newRequest(ctx context.Context, method, path string, query url.Values) (*http.Request, error) {
+	reqURL := path
+	if !strings.HasPrefix(path, "http://") && !strings.HasPrefix(path, "https://") {
+		reqURL = c.baseURL + path
+	}
+	if len(query) > 0 {
+		reqURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("kanakku: building %s request for %s: %w", method, reqURL, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	return req, nil
+}
+
+// doGet performs req, which must be a GET request, retrying on 5xx and 429
+// responses with exponential backoff and jitter. It returns an *APIError
+// for any non-2xx response that isn't worth (or able to be) retried
+// further.
+func (c *Client) doGet(req *http.Request) (*http.Response, error) {
+	var lastErr error
+
+	for attempt := 0; attempt <= c.maxRetries; attempt++ {
+		if attempt > 0 {
+			if err := c.sleepBackoff(req.Context(), attempt); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastErr = err
+			if req.Context().Err() != nil {
+				return nil, req.Context().Err()
+			}
+			continue
+		}
+
+		if resp.StatusCode < 300 {
+			return resp, nil
+		}
+
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		resp.Body.Close()
+		apiErr := &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Body: body}
+
+		if !isRetryableStatus(resp.StatusCode) || attempt == c.maxRetries {
+			return nil, apiErr
+		}
+		lastErr = apiErr
+	}
+
+	return nil, lastErr
+}
+
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// sleepBackoff waits out the exponential backoff (with full jitter) for the
+// given attempt number, or returns ctx.Err() if ctx is cancelled first.
+func (c *Client) sleepBackoff(ctx context.Context, attempt int) error {
+	delay := c.baseDelay << uint(attempt-1)
+	if delay > c.maxDelay || delay <= 0 {
+		delay = c.maxDelay
+	}
+	jittered := time.Duration(rand.Int63n(int64(delay)))
+
+	timer := time.NewTimer(jittered)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
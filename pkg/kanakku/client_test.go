@@ -0,0 +1,185 @@
+package kanakku
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/baijum/kanakku/pkg/kanakku/auth"
+)
+
+func newTestClient(baseURL string) *Client {
+	httpClient := &http.Client{
+		Transport: &auth.Transport{Source: auth.NewStaticTokenSource("Token", "testtoken")},
+	}
+	return New(baseURL, WithHTTPClient(httpClient), WithMaxRetries(2))
+}
+
+func TestGetPreambleByName_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want := "/api/v1/preambles/name/ops"; r.URL.Path != want {
+			t.Errorf("unexpected path: got %s, want %s", r.URL.Path, want)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Token testtoken" {
+			t.Errorf("unexpected Authorization header: got %q", auth)
+		}
+		json.NewEncoder(w).Encode(Preamble{ID: "42", Name: "ops"})
+	}))
+	defer server.Close()
+
+	preamble, err := newTestClient(server.URL).GetPreambleByName(context.Background(), "ops")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if preamble.ID != "42" {
+		t.Errorf("unexpected preamble ID: got %s, want 42", preamble.ID)
+	}
+}
+
+func TestGetPreambleByName_EscapesSlashInName(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want := "/api/v1/preambles/name/ops%2F2024"; r.URL.EscapedPath() != want {
+			t.Errorf("unexpected escaped path: got %s, want %s", r.URL.EscapedPath(), want)
+		}
+		json.NewEncoder(w).Encode(Preamble{ID: "42", Name: "ops/2024"})
+	}))
+	defer server.Close()
+
+	preamble, err := newTestClient(server.URL).GetPreambleByName(context.Background(), "ops/2024")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if preamble.ID != "42" {
+		t.Errorf("unexpected preamble ID: got %s, want 42", preamble.ID)
+	}
+}
+
+func TestGetPreambleByName_NotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := newTestClient(server.URL).GetPreambleByName(context.Background(), "missing")
+	var apiErr *APIError
+	if !asAPIError(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("unexpected status code: got %d, want 404", apiErr.StatusCode)
+	}
+}
+
+func TestDoGet_RetriesOn5xxThenSucceeds(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode([]Preamble{{ID: "1", Name: "a"}})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	c.baseDelay = time.Millisecond
+	c.maxDelay = time.Millisecond
+
+	preambles, err := c.ListPreambles(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(preambles) != 1 {
+		t.Fatalf("unexpected result: %+v", preambles)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("unexpected attempt count: got %d, want 3", got)
+	}
+}
+
+func TestDoGet_GivesUpAfterMaxRetries(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	c.baseDelay = time.Millisecond
+	c.maxDelay = time.Millisecond
+
+	_, err := c.ListPreambles(context.Background())
+	var apiErr *APIError
+	if !asAPIError(err, &apiErr) {
+		t.Fatalf("expected *APIError, got %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("unexpected attempt count: got %d, want 3 (1 + 2 retries)", got)
+	}
+}
+
+func TestDoGet_ContextCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	c.baseDelay = 50 * time.Millisecond
+	c.maxDelay = 50 * time.Millisecond
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := c.ListPreambles(ctx)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestListLedgerTransactions_FollowsPagination(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("cursor") != "2" {
+			json.NewEncoder(w).Encode(ledgerTransactionsPage{
+				Count:   3,
+				Next:    server.URL + "/api/v1/ledgertransactions?cursor=2",
+				Results: []LedgerTransaction{{ID: "1"}, {ID: "2"}},
+			})
+			return
+		}
+		json.NewEncoder(w).Encode(ledgerTransactionsPage{
+			Count:   3,
+			Results: []LedgerTransaction{{ID: "3"}},
+		})
+	}))
+	defer server.Close()
+
+	c := newTestClient(server.URL)
+	txns, err := c.ListLedgerTransactions(context.Background(), ListLedgerTransactionsOptions{PreambleID: "7"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(txns) != 3 {
+		t.Fatalf("unexpected transaction count: got %d, want 3", len(txns))
+	}
+	for i, txn := range txns {
+		if want := fmt.Sprintf("%d", i+1); txn.ID != want {
+			t.Errorf("unexpected transaction order at %d: got ID %s, want %s", i, txn.ID, want)
+		}
+	}
+}
+
+func asAPIError(err error, target **APIError) bool {
+	apiErr, ok := err.(*APIError)
+	if ok {
+		*target = apiErr
+	}
+	return ok
+}
@@ -0,0 +1,15 @@
+package kanakku
+
+import "fmt"
+
+// APIError is returned when the kanakku API responds with a non-2xx status
+// that either isn't retryable or survived every retry attempt.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Body       []byte
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("kanakku: API returned %s: %s", e.Status, e.Body)
+}
@@ -0,0 +1,128 @@
+package kanakku
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// ListLedgerTransactionsOptions narrows a ListLedgerTransactions or
+// ListLedgerTransactionsIterator call.
+type ListLedgerTransactionsOptions struct {
+	// PreambleID restricts results to transactions under this preamble.
+	// Required by the API.
+	PreambleID string
+}
+
+// ledgerTransactionsPage is the paginated response shape used by the
+// kanakku API's Django REST Framework pagination.
+type ledgerTransactionsPage struct {
+	Count    int                 `json:"count"`
+	Next     string              `json:"next"`
+	Previous string              `json:"previous"`
+	Results  []LedgerTransaction `json:"results"`
+}
+
+// ListLedgerTransactions fetches every matching ledger transaction,
+// following pagination until exhausted. For large result sets, prefer
+// ListLedgerTransactionsIterator so pages are streamed rather than
+// buffered in full.
+func (c *Client) ListLedgerTransactions(ctx context.Context, opts ListLedgerTransactionsOptions) ([]LedgerTransaction, error) {
+	var all []LedgerTransaction
+	it := c.ListLedgerTransactionsIterator(ctx, opts)
+	for it.Next() {
+		all = append(all, it.Transaction())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// LedgerTransactionIterator paginates through ledger transactions one page
+// at a time. Construct one with Client.ListLedgerTransactionsIterator.
+type LedgerTransactionIterator struct {
+	client  *Client
+	ctx     context.Context
+	nextURL string
+	done    bool
+
+	page []LedgerTransaction
+	cur  int
+	err  error
+}
+
+// ListLedgerTransactionsIterator returns an iterator over
+// GET /api/v1/ledgertransactions for the given options.
+func (c *Client) ListLedgerTransactionsIterator(ctx context.Context, opts ListLedgerTransactionsOptions) *LedgerTransactionIterator {
+	query := url.Values{}
+	if opts.PreambleID != "" {
+		query.Set("preamble_id", opts.PreambleID)
+	}
+	return &LedgerTransactionIterator{
+		client:  c,
+		ctx:     ctx,
+		nextURL: "/api/v1/ledgertransactions?" + query.Encode(),
+	}
+}
+
+// Next advances the iterator, fetching the next page from the API as
+// needed. It returns false once the results are exhausted or an error
+// occurs; check Err afterwards to distinguish the two.
+func (it *LedgerTransactionIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.cur < len(it.page) {
+		it.cur++
+		return true
+	}
+	if it.done {
+		return false
+	}
+
+	req, err := it.client.newRequest(it.ctx, "GET", it.nextURL, nil)
+	if err != nil {
+		it.err = err
+		return false
+	}
+
+	resp, err := it.client.doGet(req)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	defer resp.Body.Close()
+
+	var page ledgerTransactionsPage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		it.err = fmt.Errorf("kanakku: decoding ledger transactions page: %w", err)
+		return false
+	}
+
+	it.page = page.Results
+	it.cur = 0
+	if page.Next == "" {
+		it.done = true
+	} else {
+		it.nextURL = page.Next
+	}
+
+	if len(it.page) == 0 {
+		return it.Next()
+	}
+	it.cur = 1
+	return true
+}
+
+// Transaction returns the transaction the most recent call to Next
+// advanced onto.
+func (it *LedgerTransactionIterator) Transaction() LedgerTransaction {
+	return it.page[it.cur-1]
+}
+
+// Err returns the first error encountered while iterating, if any.
+func (it *LedgerTransactionIterator) Err() error {
+	return it.err
+}
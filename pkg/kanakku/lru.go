@@ -0,0 +1,42 @@
+package kanakku
+
+// idLRU is a fixed-capacity set of recently-seen IDs, used by Watch to
+// de-duplicate transactions across SSE/polling reconnects. It is not safe
+// for concurrent use.
+type idLRU struct {
+	capacity int
+	order    []string
+	seen     map[string]struct{}
+}
+
+func newIDLRU(capacity int) *idLRU {
+	return &idLRU{
+		capacity: capacity,
+		seen:     make(map[string]struct{}, capacity),
+	}
+}
+
+// SeenOrAdd reports whether id has already been recorded, marking it as the
+// most recently used entry. If not, it records id, evicting the least
+// recently used entry if the cache is at capacity.
+func (l *idLRU) SeenOrAdd(id string) bool {
+	if _, ok := l.seen[id]; ok {
+		for i, v := range l.order {
+			if v == id {
+				l.order = append(l.order[:i], l.order[i+1:]...)
+				break
+			}
+		}
+		l.order = append(l.order, id)
+		return true
+	}
+
+	if len(l.order) >= l.capacity {
+		oldest := l.order[0]
+		l.order = l.order[1:]
+		delete(l.seen, oldest)
+	}
+	l.order = append(l.order, id)
+	l.seen[id] = struct{}{}
+	return false
+}
@@ -0,0 +1,71 @@
+package kanakku
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// GetPreambleByName looks up a preamble by its unique name via
+// GET /api/v1/preambles/name/{name}. It returns an *APIError with
+// StatusCode 404 if no preamble with that name exists.
+func (c *Client) GetPreambleByName(ctx context.Context, name string) (*Preamble, error) {
+	req, err := c.newRequest(ctx, "GET", fmt.Sprintf("/api/v1/preambles/name/%s", url.PathEscape(name)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doGet(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var preamble Preamble
+	if err := json.NewDecoder(resp.Body).Decode(&preamble); err != nil {
+		return nil, fmt.Errorf("kanakku: decoding preamble %q: %w", name, err)
+	}
+	return &preamble, nil
+}
+
+// GetPreamble fetches a single preamble (including its Content) by ID via
+// GET /api/v1/preambles/{id}.
+func (c *Client) GetPreamble(ctx context.Context, id string) (*Preamble, error) {
+	req, err := c.newRequest(ctx, "GET", fmt.Sprintf("/api/v1/preambles/%s", url.PathEscape(id)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doGet(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var preamble Preamble
+	if err := json.NewDecoder(resp.Body).Decode(&preamble); err != nil {
+		return nil, fmt.Errorf("kanakku: decoding preamble %q: %w", id, err)
+	}
+	return &preamble, nil
+}
+
+// ListPreambles fetches every preamble via GET /api/v1/preambles.
+func (c *Client) ListPreambles(ctx context.Context) ([]Preamble, error) {
+	req, err := c.newRequest(ctx, "GET", "/api/v1/preambles", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doGet(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var preambles []Preamble
+	if err := json.NewDecoder(resp.Body).Decode(&preambles); err != nil {
+		return nil, fmt.Errorf("kanakku: decoding preamble list: %w", err)
+	}
+	return preambles, nil
+}
@@ -0,0 +1,25 @@
+package kanakku
+
+// LedgerTransaction is a single double-entry transaction fetched from the
+// /api/v1/ledgertransactions endpoint.
+type LedgerTransaction struct {
+	ID          string    `json:"id"`
+	Date        string    `json:"date"`
+	Description string    `json:"description"`
+	Postings    []Posting `json:"postings"`
+}
+
+// Posting is one leg of a LedgerTransaction. Amount is a decimal string
+// (e.g. "123.45" or "-123.45"), not a float, so it round-trips losslessly.
+type Posting struct {
+	Account string `json:"account"`
+	Amount  string `json:"amount"`
+}
+
+// Preamble is the header text associated with a ledger, fetched from the
+// /api/v1/preambles endpoints.
+type Preamble struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
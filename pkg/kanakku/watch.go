@@ -0,0 +1,237 @@
+package kanakku
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dedupCacheSize      = 1024
+	defaultPollInterval = 5 * time.Second
+)
+
+// errSSEUnsupported signals that the server has no SSE stream endpoint, so
+// Watch should fall back to polling for the rest of its lifetime.
+var errSSEUnsupported = errors.New("kanakku: SSE stream endpoint not available")
+
+// WatchOptions narrows a Watch call.
+type WatchOptions struct {
+	// PreambleID restricts the stream to transactions under this preamble.
+	PreambleID string
+	// PollInterval is how often to poll when falling back from SSE.
+	// Defaults to 5s.
+	PollInterval time.Duration
+}
+
+// Watch streams ledger transactions as they appear, via Server-Sent Events
+// when available (GET /api/v1/ledgertransactions/stream) and falling back
+// to polling ?since=<last_seen_id> otherwise. It reconnects with
+// exponential backoff and jitter, and de-duplicates transactions across
+// reconnects. Both returned channels are closed when ctx is cancelled.
+func (c *Client) Watch(ctx context.Context, opts WatchOptions) (<-chan LedgerTransaction, <-chan error) {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+
+	out := make(chan LedgerTransaction)
+	errCh := make(chan error, 1)
+	go c.watchLoop(ctx, opts, out, errCh)
+	return out, errCh
+}
+
+func (c *Client) watchLoop(ctx context.Context, opts WatchOptions, out chan<- LedgerTransaction, errCh chan<- error) {
+	defer close(out)
+	defer close(errCh)
+
+	dedup := newIDLRU(dedupCacheSize)
+	sseSupported := true
+	lastSeenID := ""
+	attempt := 0
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if sseSupported {
+			retryAfter, err := c.streamSSE(ctx, opts, out, dedup)
+			if errors.Is(err, errSSEUnsupported) {
+				sseSupported = false
+				attempt = 0
+				continue
+			}
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				select {
+				case errCh <- err:
+				case <-ctx.Done():
+					return
+				}
+			}
+			attempt++
+			if waitErr := c.backoffWait(ctx, attempt, retryAfter); waitErr != nil {
+				return
+			}
+			continue
+		}
+
+		if err := c.pollOnce(ctx, opts, &lastSeenID, out, dedup); err != nil {
+			select {
+			case errCh <- err:
+			case <-ctx.Done():
+				return
+			}
+			attempt++
+			if waitErr := c.backoffWait(ctx, attempt, 0); waitErr != nil {
+				return
+			}
+			continue
+		}
+		attempt = 0
+		if waitErr := c.backoffWait(ctx, 0, opts.PollInterval); waitErr != nil {
+			return
+		}
+	}
+}
+
+// backoffWait sleeps for override if set, otherwise the exponential
+// backoff for attempt (attempt 0 means "just sleep override").
+func (c *Client) backoffWait(ctx context.Context, attempt int, override time.Duration) error {
+	if override > 0 {
+		timer := time.NewTimer(override)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		}
+	}
+	if attempt <= 0 {
+		return nil
+	}
+	return c.sleepBackoff(ctx, attempt)
+}
+
+// streamSSE opens the SSE stream and blocks, emitting transactions until
+// the connection drops or ctx is cancelled. It returns errSSEUnsupported
+// if the server has no stream endpoint, and the SSE "retry:" field value
+// (if any) to use as the reconnect delay.
+func (c *Client) streamSSE(ctx context.Context, opts WatchOptions, out chan<- LedgerTransaction, dedup *idLRU) (time.Duration, error) {
+	query := url.Values{}
+	if opts.PreambleID != "" {
+		query.Set("preamble_id", opts.PreambleID)
+	}
+
+	req, err := c.newRequest(ctx, "GET", "/api/v1/ledgertransactions/stream", query)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return 0, errSSEUnsupported
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		return 0, &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Body: body}
+	}
+
+	var retry time.Duration
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "data:"):
+			payload := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+			if payload == "" {
+				continue
+			}
+			var txn LedgerTransaction
+			if err := json.Unmarshal([]byte(payload), &txn); err != nil {
+				continue
+			}
+			if dedup.SeenOrAdd(txn.ID) {
+				continue
+			}
+			select {
+			case out <- txn:
+			case <-ctx.Done():
+				return retry, ctx.Err()
+			}
+		case strings.HasPrefix(line, "retry:"):
+			if ms, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(line, "retry:"))); err == nil {
+				retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return retry, err
+	}
+	return retry, io.ErrUnexpectedEOF
+}
+
+// ledgerTransactionsSincePage mirrors ledgerTransactionsPage for the
+// ?since= polling endpoint, which doesn't paginate further: it always
+// returns the (small) set of transactions newer than since.
+type ledgerTransactionsSincePage struct {
+	Results []LedgerTransaction `json:"results"`
+}
+
+// pollOnce fetches transactions newer than *lastSeenID and emits any not
+// already seen, advancing *lastSeenID as it goes.
+func (c *Client) pollOnce(ctx context.Context, opts WatchOptions, lastSeenID *string, out chan<- LedgerTransaction, dedup *idLRU) error {
+	query := url.Values{}
+	if opts.PreambleID != "" {
+		query.Set("preamble_id", opts.PreambleID)
+	}
+	if *lastSeenID != "" {
+		query.Set("since", *lastSeenID)
+	}
+
+	req, err := c.newRequest(ctx, "GET", "/api/v1/ledgertransactions", query)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doGet(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var page ledgerTransactionsSincePage
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return fmt.Errorf("kanakku: decoding polled transactions: %w", err)
+	}
+
+	for _, txn := range page.Results {
+		if dedup.SeenOrAdd(txn.ID) {
+			continue
+		}
+		select {
+		case out <- txn:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		*lastSeenID = txn.ID
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package kanakku
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestIDLRU_SeenOrAdd(t *testing.T) {
+	l := newIDLRU(2)
+
+	if l.SeenOrAdd("a") {
+		t.Fatal("expected \"a\" to be unseen on first add")
+	}
+	if !l.SeenOrAdd("a") {
+		t.Fatal("expected \"a\" to be seen on second add")
+	}
+
+	if l.SeenOrAdd("b") {
+		t.Fatal("expected \"b\" to be unseen on first add")
+	}
+	// Capacity is 2 and both slots are full ("a", "b"); adding "c" must
+	// evict the oldest entry, "a".
+	if l.SeenOrAdd("c") {
+		t.Fatal("expected \"c\" to be unseen on first add")
+	}
+
+	if !l.SeenOrAdd("b") {
+		t.Error("expected \"b\" to still be cached as seen")
+	}
+	if !l.SeenOrAdd("c") {
+		t.Error("expected \"c\" to still be cached as seen")
+	}
+}
+
+func TestWatch_SSE(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/ledgertransactions/stream" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if accept := r.Header.Get("Accept"); accept != "text/event-stream" {
+			t.Errorf("unexpected Accept header: %s", accept)
+		}
+
+		flusher := w.(http.Flusher)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, "data: {\"id\":\"1\",\"description\":\"first\"}\n\n")
+		flusher.Flush()
+		fmt.Fprintf(w, "data: {\"id\":\"2\",\"description\":\"second\"}\n\n")
+		flusher.Flush()
+		// Then the connection is left open until the client disconnects,
+		// simulating a long-lived stream.
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := newTestClient(server.URL)
+	txns, errCh := c.Watch(ctx, WatchOptions{})
+
+	var got []LedgerTransaction
+	for len(got) < 2 {
+		select {
+		case txn := <-txns:
+			got = append(got, txn)
+		case err := <-errCh:
+			t.Fatalf("unexpected error: %v", err)
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for transactions")
+		}
+	}
+
+	if got[0].ID != "1" || got[1].ID != "2" {
+		t.Errorf("unexpected transactions: %+v", got)
+	}
+}
+
+// TestWatch_SSEReconnectsAndDeduplicates drops the SSE connection after the
+// first event and asserts the client reconnects (instead of giving up or
+// falling back to polling) and that the id re-sent across the reconnect is
+// suppressed by the dedup cache.
+func TestWatch_SSEReconnectsAndDeduplicates(t *testing.T) {
+	var connections int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher := w.(http.Flusher)
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+
+		if atomic.AddInt32(&connections, 1) == 1 {
+			// First connection: emit id "1" then drop, as if the network
+			// connection died.
+			fmt.Fprintf(w, "data: {\"id\":\"1\",\"description\":\"first\"}\n\n")
+			flusher.Flush()
+			return
+		}
+
+		// Reconnect: re-send "1" (must be deduplicated) and a new "2",
+		// then stay open until the client disconnects.
+		fmt.Fprintf(w, "data: {\"id\":\"1\",\"description\":\"first\"}\n\n")
+		fmt.Fprintf(w, "data: {\"id\":\"2\",\"description\":\"second\"}\n\n")
+		flusher.Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := newTestClient(server.URL)
+	c.baseDelay = time.Millisecond
+	c.maxDelay = time.Millisecond
+	txns, errCh := c.Watch(ctx, WatchOptions{})
+
+	// A dropped connection surfaces on errCh before the client reconnects;
+	// that's expected here, not a failure.
+	var got []LedgerTransaction
+	for len(got) < 2 {
+		select {
+		case txn := <-txns:
+			got = append(got, txn)
+		case <-errCh:
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for transactions")
+		}
+	}
+
+	if got[0].ID != "1" || got[1].ID != "2" {
+		t.Errorf("unexpected transactions: %+v (expected re-sent id \"1\" to be deduplicated)", got)
+	}
+	if atomic.LoadInt32(&connections) < 2 {
+		t.Errorf("expected the client to reconnect after the dropped connection, got %d connection(s)", connections)
+	}
+
+	// Give the reconnect loop a moment to deliver anything extra, then
+	// confirm no duplicate "1" slipped through.
+	timeout := time.After(50 * time.Millisecond)
+	for {
+		select {
+		case txn := <-txns:
+			t.Errorf("unexpected extra transaction after dedup: %+v", txn)
+		case <-errCh:
+		case <-timeout:
+			return
+		}
+	}
+}
+
+func TestWatch_PollingFallbackOn404(t *testing.T) {
+	var polls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/ledgertransactions/stream":
+			w.WriteHeader(http.StatusNotFound)
+		case "/api/v1/ledgertransactions":
+			polls++
+			since := r.URL.Query().Get("since")
+			if since == "" {
+				fmt.Fprint(w, `{"results":[{"id":"1","description":"first"}]}`)
+				return
+			}
+			fmt.Fprint(w, `{"results":[]}`)
+		default:
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := newTestClient(server.URL)
+	c.baseDelay = time.Millisecond
+	c.maxDelay = time.Millisecond
+	txns, errCh := c.Watch(ctx, WatchOptions{PollInterval: time.Millisecond})
+
+	select {
+	case txn := <-txns:
+		if txn.ID != "1" {
+			t.Errorf("unexpected transaction: %+v", txn)
+		}
+	case err := <-errCh:
+		t.Fatalf("unexpected error: %v", err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for transaction")
+	}
+}